@@ -0,0 +1,15 @@
+// Package devfile is the top-level entry point for parsing a devfile.
+package devfile
+
+import (
+	"github.com/devfile/api/v2/pkg/validation/variables"
+
+	"github.com/devfile/library/pkg/devfile/parser"
+)
+
+// ParseDevfileAndValidate parses, resolves, and validates a devfile per args. See
+// parser.ParserArgs for the supported sources (Data/Path/URL) and the optional metrics,
+// remote-fetch, and verification configuration.
+func ParseDevfileAndValidate(args parser.ParserArgs) (parser.DevfileObj, variables.VariableWarning, error) {
+	return parser.ParseDevfile(args)
+}
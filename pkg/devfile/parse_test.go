@@ -1,6 +1,11 @@
 package devfile
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"net"
 	"net/http"
@@ -9,11 +14,319 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/devfile/api/v2/pkg/validation/variables"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/devfile/library/pkg/devfile/parser/verify"
 )
 
+// TestParseDevfileAndValidateMetricsRegisterer parses the same devfile twice against one
+// ParserArgs.MetricsRegisterer, the way a controller reconciling the same devfile on every
+// loop would, and confirms the second call doesn't fail with a duplicate-registration error.
+func TestParseDevfileAndValidateMetricsRegisterer(t *testing.T) {
+	devfileContent := `commands:
+- exec:
+    commandLine: ./main
+    component: runtime
+    group:
+      isDefault: true
+      kind: run
+    workingDir: ${PROJECT_SOURCE}
+  id: run
+components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 8080
+    image: golang:latest
+    memoryLimit: 1024Mi
+    mountSources: true
+  name: runtime
+metadata:
+  name: my-go-app
+  projectType: go
+  version: 1.0.0
+schemaVersion: 2.2.0
+`
+
+	reg := prometheus.NewRegistry()
+	args := parser.ParserArgs{
+		Data:              []byte(devfileContent),
+		MetricsRegisterer: reg,
+	}
+
+	if _, _, err := ParseDevfileAndValidate(args); err != nil {
+		t.Fatalf("ParseDevfileAndValidate() first call unexpected error: %v", err)
+	}
+	if _, _, err := ParseDevfileAndValidate(args); err != nil {
+		t.Fatalf("ParseDevfileAndValidate() second call against the same MetricsRegisterer unexpected error: %v", err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() unexpected error: %v", err)
+	}
+	byName := map[string]bool{}
+	for _, f := range families {
+		byName[f.GetName()] = true
+	}
+	if !byName["devfile_parser_parses_total"] {
+		t.Errorf("expected devfile_parser_parses_total to be registered and populated, got families %v", byName)
+	}
+}
+
+// TestParseDevfileAndValidateURIDigestAttribute confirms a devfile.io/uri-sha256 attribute
+// pinned directly on a Kubernetes component, rather than ParserArgs.ExpectedDigests, is
+// actually enforced by ParseDevfileAndValidate, and that a verified fetch gets the
+// devfile.io/kubeComponent-digest attribute stamped.
+func TestParseDevfileAndValidateURIDigestAttribute(t *testing.T) {
+	const outerloopDeployContent = "kind: Deployment\nmetadata:\n  name: my-python\n"
+	digest := verify.Digest([]byte(outerloopDeployContent))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(outerloopDeployContent))
+	}))
+	defer server.Close()
+
+	newDevfileContent := func(pinnedDigest string) string {
+		return fmt.Sprintf(`commands:
+- exec:
+    commandLine: ./main
+    component: runtime
+    group:
+      isDefault: true
+      kind: run
+    workingDir: ${PROJECT_SOURCE}
+  id: run
+components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 8080
+    image: golang:latest
+    memoryLimit: 1024Mi
+    mountSources: true
+  name: runtime
+- kubernetes:
+    uri: %s
+  name: outerloop-deploy
+  attributes:
+    devfile.io/uri-sha256: %s
+metadata:
+  name: my-go-app
+  projectType: go
+  version: 1.0.0
+schemaVersion: 2.2.0
+`, server.URL, pinnedDigest)
+	}
+
+	t.Run("matching pinned digest is verified and stamped", func(t *testing.T) {
+		gotD, _, err := ParseDevfileAndValidate(parser.ParserArgs{Data: []byte(newDevfileContent(digest))})
+		if err != nil {
+			t.Fatalf("ParseDevfileAndValidate() error = %v, wantErr nil", err)
+		}
+
+		kubeComponents, err := gotD.Data.GetComponents(common.DevfileOptions{
+			ComponentOptions: common.ComponentOptions{ComponentType: v1.KubernetesComponentType},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error getting kubernetes component: %v", err)
+		}
+
+		var getErr error
+		if got := kubeComponents[0].Attributes.GetString(verify.VerifiedDigestAttributeKey, &getErr); getErr != nil || got != digest {
+			t.Errorf("%s = %q (err %v), want %q", verify.VerifiedDigestAttributeKey, got, getErr, digest)
+		}
+	})
+
+	t.Run("mismatched pinned digest fails the parse", func(t *testing.T) {
+		_, _, err := ParseDevfileAndValidate(parser.ParserArgs{Data: []byte(newDevfileContent("deadbeef"))})
+		if err == nil {
+			t.Error("ParseDevfileAndValidate() with a mismatched devfile.io/uri-sha256 attribute returned nil error")
+		}
+	})
+}
+
+// TestParseDevfileAndValidateResourceResolver confirms ParserArgs.ResourceResolver is actually
+// used by ParseDevfileAndValidate to fetch a kubernetes.uri, rather than only by resolver.*
+// types in isolation.
+func TestParseDevfileAndValidateResourceResolver(t *testing.T) {
+	const outerloopDeployContent = "kind: Deployment\nmetadata:\n  name: my-python\n"
+	const fakeURI = "fake://devfile-store/outerloop-deploy.yaml"
+
+	devfileContent := fmt.Sprintf(`commands:
+- exec:
+    commandLine: ./main
+    component: runtime
+    group:
+      isDefault: true
+      kind: run
+    workingDir: ${PROJECT_SOURCE}
+  id: run
+components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 8080
+    image: golang:latest
+    memoryLimit: 1024Mi
+    mountSources: true
+  name: runtime
+- kubernetes:
+    uri: %s
+  name: outerloop-deploy
+metadata:
+  name: my-go-app
+  projectType: go
+  version: 1.0.0
+schemaVersion: 2.2.0
+`, fakeURI)
+
+	resolver := &recordingResolver{content: []byte(outerloopDeployContent)}
+	gotD, _, err := ParseDevfileAndValidate(parser.ParserArgs{
+		Data:             []byte(devfileContent),
+		ResourceResolver: resolver,
+	})
+	if err != nil {
+		t.Fatalf("ParseDevfileAndValidate() error = %v, wantErr nil", err)
+	}
+	if resolver.calls != 1 || resolver.lastURI != fakeURI {
+		t.Errorf("expected ResourceResolver.Resolve to be called once with %q, got %d calls, last URI %q", fakeURI, resolver.calls, resolver.lastURI)
+	}
+
+	kubeComponents, err := gotD.Data.GetComponents(common.DevfileOptions{
+		ComponentOptions: common.ComponentOptions{ComponentType: v1.KubernetesComponentType},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting kubernetes component: %v", err)
+	}
+	if kubeComponents[0].Kubernetes.Inlined != outerloopDeployContent {
+		t.Errorf("Kubernetes.Inlined = %q, want %q", kubeComponents[0].Kubernetes.Inlined, outerloopDeployContent)
+	}
+}
+
+// recordingResolver is a parser/resolver.ResourceResolver stand-in used to confirm
+// ParserArgs.ResourceResolver is actually consulted by the real parse path.
+type recordingResolver struct {
+	content []byte
+	calls   int
+	lastURI string
+}
+
+func (r *recordingResolver) Resolve(ctx context.Context, uri string) ([]byte, error) {
+	r.calls++
+	r.lastURI = uri
+	return r.content, nil
+}
+
+// TestParseDevfileAndValidateOCIKubernetesComponent mirrors TestParseDevfileAndValidate's
+// http:// kubernetes.uri case, but with an oci:// reference served by a local OCI distribution
+// API registry, to confirm ParserArgs.HTTPClient reaches the OCI resolver end-to-end and the
+// inlined content / original-URI attribute come out the same way.
+func TestParseDevfileAndValidateOCIKubernetesComponent(t *testing.T) {
+	KubeComponentOriginalURIKey := "devfile.io/kubeComponent-originalURI"
+	const layerMediaType = "application/vnd.devfile.layer.v1+yaml"
+	const outerloopDeployContent = `kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: my-python
+`
+	sum := sha256.Sum256([]byte(outerloopDeployContent))
+	layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	type ociLayer struct {
+		MediaType string `json:"mediaType"`
+		Digest    string `json:"digest"`
+		Size      int64  `json:"size"`
+	}
+	type ociManifest struct {
+		SchemaVersion int        `json:"schemaVersion"`
+		MediaType     string     `json:"mediaType"`
+		Layers        []ociLayer `json:"layers"`
+	}
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		Layers: []ociLayer{
+			{MediaType: layerMediaType, Digest: layerDigest, Size: int64(len(outerloopDeployContent))},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/devfile/outerloop/manifests/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(manifest)
+	})
+	mux.HandleFunc("/v2/devfile/outerloop/blobs/"+layerDigest, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(outerloopDeployContent))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	registry := server.Listener.Addr().String()
+
+	devfileContent := fmt.Sprintf(`commands:
+- exec:
+    commandLine: ./main
+    component: runtime
+    group:
+      isDefault: true
+      kind: run
+    workingDir: ${PROJECT_SOURCE}
+  id: run
+components:
+- container:
+    endpoints:
+    - name: http
+      targetPort: 8080
+    image: golang:latest
+    memoryLimit: 1024Mi
+    mountSources: true
+  name: runtime
+- kubernetes:
+    uri: oci://%s/devfile/outerloop:1.0.0
+  name: outerloop-deploy
+metadata:
+  description: Stack with the latest Go version
+  displayName: Go Runtime
+  language: go
+  name: my-go-app
+  projectType: go
+  tags:
+  - Go
+  version: 1.0.0
+schemaVersion: 2.2.0
+`, registry)
+
+	gotD, _, err := ParseDevfileAndValidate(parser.ParserArgs{
+		Data:       []byte(devfileContent),
+		HTTPClient: server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("ParseDevfileAndValidate() error = %v, wantErr nil", err)
+	}
+
+	kubeComponents, err := gotD.Data.GetComponents(common.DevfileOptions{
+		ComponentOptions: common.ComponentOptions{ComponentType: v1.KubernetesComponentType},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting kubernetes component: %v", err)
+	}
+	kubernetesComponent := kubeComponents[0]
+
+	if kubernetesComponent.Kubernetes.Uri != "" || kubernetesComponent.Kubernetes.Inlined != outerloopDeployContent {
+		t.Errorf("unexpected kubernetes component inlined, got %q, want %q (and empty Uri)", kubernetesComponent.Kubernetes.Inlined, outerloopDeployContent)
+	}
+
+	wantOriginalURI := fmt.Sprintf("oci://%s/devfile/outerloop:1.0.0", registry)
+	if kubernetesComponent.Attributes == nil {
+		t.Fatal("ParseDevfileAndValidate() should set kubernetesComponent.Attributes, but got empty Attributes")
+	}
+	if originalURI := kubernetesComponent.Attributes.GetString(KubeComponentOriginalURIKey, &err); err != nil || originalURI != wantOriginalURI {
+		t.Errorf("ParseDevfileAndValidate() should set kubernetesComponent.Attributes[%q] = %q, got %q", KubeComponentOriginalURIKey, wantOriginalURI, originalURI)
+	}
+}
+
 func TestParseDevfileAndValidate(t *testing.T) {
 	KubeComponentOriginalURIKey := "devfile.io/kubeComponent-originalURI"
 	outerloopDeployContent := `
@@ -214,6 +527,13 @@ schemaVersion: 2.2.0
 					t.Errorf("ParseDevfileAndValidate() should set kubenetesComponent.Attributes, '%s', expected http://127.0.0.1:8080/outerloop-deploy.yaml, got %s",
 						KubeComponentOriginalURIKey, originalUri)
 				}
+				// None of these test cases configure digest/signature verification, so the
+				// fetch was never actually checked: kubeComponent-digest must stay unset rather
+				// than look indistinguishable from a verified fetch.
+				var digestErr error
+				if digest := kubenetesComponent.Attributes.GetString(verify.VerifiedDigestAttributeKey, &digestErr); digestErr == nil {
+					t.Errorf("ParseDevfileAndValidate() should leave %s unset when no digest/verifier is configured, got %q", verify.VerifiedDigestAttributeKey, digest)
+				}
 			} else {
 				t.Error("ParseDevfileAndValidate() should set kubenetesComponent.Attributes, but got empty Attributes")
 			}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"net/http"
+
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/parser/metrics"
+	"github.com/devfile/library/pkg/devfile/parser/resolver"
+	"github.com/devfile/library/pkg/devfile/parser/verify"
+)
+
+// ParserArgs configures a single devfile parse. Exactly one of Path, URL, or Data should be set
+// to say where the devfile itself comes from; everything else is optional and controls how
+// referenced content (kubernetes.uri, parent.uri, plugin uris) is fetched and observed.
+type ParserArgs struct {
+	// Path is a local filesystem path to the devfile.
+	Path string
+	// URL is a remote URL the devfile itself is fetched from.
+	URL string
+	// Data is the raw devfile content. Mutually exclusive with Path and URL.
+	Data []byte
+
+	// ExternalVariables overrides (or adds to) the devfile's own variables section before
+	// variable substitution runs.
+	ExternalVariables map[string]string
+
+	// MetricsRegisterer, when set, receives the parser's Prometheus collectors (parse
+	// counts/duration, remote-fetch duration, variable-warning counts; see package metrics).
+	// Left nil, the parser stays silent.
+	MetricsRegisterer metrics.Registerer
+
+	// HTTPClient configures the default HTTP(S) resolver used for kubernetes.uri, parent.uri,
+	// and plugin references when ResourceResolver is left unset. Left nil, http.DefaultClient
+	// is used.
+	HTTPClient *http.Client
+
+	// ResourceResolver overrides how kubernetes.uri, parent.uri, and plugin references are
+	// fetched, e.g. with resolver.CachingResolver to avoid re-fetching an unchanged reference
+	// on every reconcile. Left unset, the parser resolves http(s):// URIs with
+	// resolver.NewHTTPResolver(HTTPClient) and oci:// URIs with resolver.NewOCIResolver.
+	ResourceResolver resolver.ResourceResolver
+
+	// DockerConfigPath configures registry auth for oci:// references resolved by the default
+	// resolver, following the same precedence as the docker/podman CLIs: DockerConfigPath,
+	// then $DOCKER_CONFIG, then ~/.docker/config.json. Ignored when ResourceResolver is set.
+	DockerConfigPath string
+
+	// ExpectedDigests pins the expected sha256 digest (hex-encoded, see verify.Digest) of
+	// fetched content, keyed by the URI it was fetched from. A mismatch fails the parse with a
+	// *verify.DigestMismatchError.
+	ExpectedDigests map[string]string
+
+	// Verifier, when set, validates a detached signature fetched from "<uri>.sig" for every
+	// kubernetes.uri, parent.uri, and plugin reference resolved.
+	Verifier verify.Verifier
+}
+
+// DevfileObj is the result of a successful parse.
+type DevfileObj struct {
+	Data data.DevfileData
+}
@@ -0,0 +1,23 @@
+// Package data is the in-memory representation of a parsed devfile, addressable through
+// schema-version-agnostic getters so the parser and its callers don't need to know which
+// schemaVersion produced a given DevfileData.
+package data
+
+import (
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+)
+
+// DevfileData is the in-memory representation of a single devfile.
+type DevfileData interface {
+	GetSchemaVersion() string
+	GetCommands(options common.DevfileOptions) ([]v1.Command, error)
+	GetComponents(options common.DevfileOptions) ([]v1.Component, error)
+	GetDevfileWorkspaceSpec() v1.DevWorkspaceTemplateSpec
+
+	// GetDevfileWorkspaceSpecContent returns a pointer to the underlying spec content so
+	// callers (parent/plugin resolution, variable substitution) can mutate Commands,
+	// Components, and Variables in place.
+	GetDevfileWorkspaceSpecContent() *v1.DevWorkspaceTemplateSpecContent
+}
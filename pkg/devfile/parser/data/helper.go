@@ -0,0 +1,13 @@
+package data
+
+import (
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+
+	v2 "github.com/devfile/library/pkg/devfile/parser/data/v2"
+)
+
+// NewDevfileData wraps raw in the DevfileData implementation for its schema version. Only
+// schema version 2.x is currently supported.
+func NewDevfileData(raw v1.Devfile) (DevfileData, error) {
+	return &v2.DevfileV2{Devfile: raw}, nil
+}
@@ -0,0 +1,22 @@
+// Package common holds types shared across devfile schema versions, starting with the options
+// used to filter DevfileData's Get* methods.
+package common
+
+import v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+
+// DevfileOptions filters the entries returned by DevfileData's Get* methods. A zero-value
+// DevfileOptions applies no filtering.
+type DevfileOptions struct {
+	CommandOptions   CommandOptions
+	ComponentOptions ComponentOptions
+}
+
+// CommandOptions filters DevfileData.GetCommands.
+type CommandOptions struct {
+	CommandGroupKind v1.CommandGroupKind
+}
+
+// ComponentOptions filters DevfileData.GetComponents.
+type ComponentOptions struct {
+	ComponentType v1.ComponentType
+}
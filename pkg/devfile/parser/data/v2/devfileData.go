@@ -0,0 +1,72 @@
+// Package v2 implements data.DevfileData for devfile schemaVersion 2.x.
+package v2
+
+import (
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+)
+
+// DevfileV2 wraps a v1alpha2.Devfile to satisfy the data.DevfileData interface.
+type DevfileV2 struct {
+	v1.Devfile
+}
+
+func (d *DevfileV2) GetSchemaVersion() string {
+	return d.SchemaVersion
+}
+
+func (d *DevfileV2) GetCommands(options common.DevfileOptions) ([]v1.Command, error) {
+	if options.CommandOptions.CommandGroupKind == "" {
+		return d.Commands, nil
+	}
+	var filtered []v1.Command
+	for _, c := range d.Commands {
+		if c.Exec != nil && c.Exec.Group != nil && c.Exec.Group.Kind == options.CommandOptions.CommandGroupKind {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+func (d *DevfileV2) GetComponents(options common.DevfileOptions) ([]v1.Component, error) {
+	if options.ComponentOptions.ComponentType == "" {
+		return d.Components, nil
+	}
+	var filtered []v1.Component
+	for _, c := range d.Components {
+		if componentType(c) == options.ComponentOptions.ComponentType {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+func (d *DevfileV2) GetDevfileWorkspaceSpec() v1.DevWorkspaceTemplateSpec {
+	return d.DevWorkspaceTemplateSpec
+}
+
+func (d *DevfileV2) GetDevfileWorkspaceSpecContent() *v1.DevWorkspaceTemplateSpecContent {
+	return &d.DevWorkspaceTemplateSpecContent
+}
+
+func componentType(c v1.Component) v1.ComponentType {
+	switch {
+	case c.Kubernetes != nil:
+		return v1.KubernetesComponentType
+	case c.Openshift != nil:
+		return v1.OpenshiftComponentType
+	case c.Container != nil:
+		return v1.ContainerComponentType
+	case c.Plugin != nil:
+		return v1.PluginComponentType
+	case c.Volume != nil:
+		return v1.VolumeComponentType
+	case c.Image != nil:
+		return v1.ImageComponentType
+	case c.Custom != nil:
+		return v1.CustomComponentType
+	default:
+		return ""
+	}
+}
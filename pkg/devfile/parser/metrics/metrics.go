@@ -0,0 +1,150 @@
+// Package metrics provides optional Prometheus instrumentation for the devfile parser.
+//
+// Callers that run the parser inside a long-running service (e.g. an operator reconcile
+// loop) can set parser.ParserArgs.MetricsRegisterer to a prometheus.Registerer before calling
+// parser.ParseDevfile / devfile.ParseDevfileAndValidate to get parse counts, remote-fetch
+// latency, and variable-warning counts broken out by schema version and failure class. When
+// MetricsRegisterer is left nil the parser never touches Prometheus and behaves exactly as
+// before.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "devfile_parser"
+
+// Outcome is the label value recorded on ParseTotal for a single parse attempt.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// FailureClass enumerates the stages of ParseDevfileAndValidate that can fail, used as a label
+// value so operators can tell a bad remote mirror apart from a schema validation error.
+type FailureClass string
+
+const (
+	FailureClassNone                 FailureClass = ""
+	FailureClassFetch                FailureClass = "fetch"
+	FailureClassUnmarshal            FailureClass = "unmarshal"
+	FailureClassValidate             FailureClass = "validate"
+	FailureClassVariableSubstitution FailureClass = "variable-substitution"
+)
+
+// VariableWarningKind mirrors the fields of variables.VariableWarning, used as a label value
+// on VariableWarningsTotal.
+type VariableWarningKind string
+
+const (
+	VariableWarningKindCommands        VariableWarningKind = "Commands"
+	VariableWarningKindComponents      VariableWarningKind = "Components"
+	VariableWarningKindProjects        VariableWarningKind = "Projects"
+	VariableWarningKindStarterProjects VariableWarningKind = "StarterProjects"
+)
+
+// Registerer is the subset of prometheus.Registerer the parser depends on, so callers can hand
+// it a wrapper (e.g. a controller-runtime metrics.Registry) without this package importing
+// anything beyond client_golang.
+type Registerer interface {
+	Register(prometheus.Collector) error
+}
+
+// Recorder wraps the collectors registered for a single parser instance. A nil *Recorder is
+// valid and every method on it is a no-op, so code that always calls through a *Recorder
+// doesn't need a separate "metrics enabled" check.
+type Recorder struct {
+	parseTotal       *prometheus.CounterVec
+	parseDuration    *prometheus.HistogramVec
+	fetchDuration    *prometheus.HistogramVec
+	variableWarnings *prometheus.CounterVec
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = map[Registerer]*Recorder{}
+)
+
+// NewRecorder returns the Recorder registered against reg, registering the devfile parser
+// collectors on first use and reusing them on every later call. Prometheus registration is
+// keyed on a collector's descriptor (namespace, name, and labels), not instance identity, so a
+// caller that parses repeatedly against the same Registerer — an operator reconcile loop, the
+// use case this package exists for — would otherwise hit a duplicate-registration error on the
+// second call. It returns an error if registration fails for any other reason, e.g. reg already
+// has a different collector registered under the same fully-qualified name.
+func NewRecorder(reg Registerer) (*Recorder, error) {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+
+	if r, ok := recorders[reg]; ok {
+		return r, nil
+	}
+
+	r := &Recorder{
+		parseTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "parses_total",
+			Help:      "Total number of ParseDevfileAndValidate attempts, by schema version, outcome and failure class.",
+		}, []string{"schema_version", "outcome", "failure_class"}),
+		parseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "parse_duration_seconds",
+			Help:      "Time to parse and validate a devfile end-to-end, by schema version.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"schema_version"}),
+		fetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "remote_fetch_duration_seconds",
+			Help:      "Time to fetch a remote devfile reference (kubernetes.uri, parent, or plugin), by kind.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"kind"}),
+		variableWarnings: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "variable_warnings_total",
+			Help:      "Occurrences of unresolved variable warnings, by devfile section.",
+		}, []string{"kind"}),
+	}
+
+	for _, c := range []prometheus.Collector{r.parseTotal, r.parseDuration, r.fetchDuration, r.variableWarnings} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	recorders[reg] = r
+	return r, nil
+}
+
+// ObserveParse records the outcome and duration of a single ParseDevfileAndValidate call.
+// failureClass is ignored when outcome is OutcomeSuccess.
+func (r *Recorder) ObserveParse(schemaVersion string, outcome Outcome, failureClass FailureClass, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	if outcome == OutcomeSuccess {
+		failureClass = FailureClassNone
+	}
+	r.parseTotal.WithLabelValues(schemaVersion, string(outcome), string(failureClass)).Inc()
+	r.parseDuration.WithLabelValues(schemaVersion).Observe(duration.Seconds())
+}
+
+// ObserveFetch records the duration of a single remote fetch (kubernetes.uri, parent.uri, or a
+// plugin reference).
+func (r *Recorder) ObserveFetch(kind string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.fetchDuration.WithLabelValues(kind).Observe(duration.Seconds())
+}
+
+// IncVariableWarning increments the warning counter for a single devfile section.
+func (r *Recorder) IncVariableWarning(kind VariableWarningKind) {
+	if r == nil {
+		return
+	}
+	r.variableWarnings.WithLabelValues(string(kind)).Inc()
+}
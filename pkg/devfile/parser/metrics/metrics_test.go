@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestNewRecorderRegistersCollectors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("NewRecorder() unexpected error: %v", err)
+	}
+
+	r.ObserveParse("2.2.0", OutcomeFailure, FailureClassFetch, 10*time.Millisecond)
+	r.ObserveFetch("kubernetes.uri", 5*time.Millisecond)
+	r.IncVariableWarning(VariableWarningKindCommands)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() unexpected error: %v", err)
+	}
+
+	byName := map[string]*dto.MetricFamily{}
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+
+	for _, name := range []string{
+		namespace + "_parses_total",
+		namespace + "_parse_duration_seconds",
+		namespace + "_remote_fetch_duration_seconds",
+		namespace + "_variable_warnings_total",
+	} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("expected metric family %q to be registered, got families %v", name, byName)
+		}
+	}
+}
+
+func TestNewRecorderReusesRegistrationForSameRegisterer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	first, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("NewRecorder() unexpected error: %v", err)
+	}
+
+	// A second call against the same Registerer, as happens on every reconcile of a
+	// long-running operator, must not attempt to re-register the same collectors.
+	second, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("NewRecorder() on a second call unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("NewRecorder() returned a different *Recorder on the second call for the same Registerer")
+	}
+}
+
+func TestNilRecorderIsNoOp(t *testing.T) {
+	var r *Recorder
+	r.ObserveParse("2.2.0", OutcomeSuccess, FailureClassNone, time.Millisecond)
+	r.ObserveFetch("parent", time.Millisecond)
+	r.IncVariableWarning(VariableWarningKindProjects)
+}
@@ -0,0 +1,388 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	apiattributes "github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/api/v2/pkg/validation/variables"
+	"sigs.k8s.io/yaml"
+
+	"github.com/devfile/library/pkg/devfile/parser/data"
+	"github.com/devfile/library/pkg/devfile/parser/metrics"
+	"github.com/devfile/library/pkg/devfile/parser/resolver"
+	"github.com/devfile/library/pkg/devfile/parser/verify"
+)
+
+// KubeComponentOriginalURIKey records the original kubernetes.uri a Kubernetes component was
+// inlined from. Components merged in from a parent or plugin reference are not stamped with it.
+const KubeComponentOriginalURIKey = "devfile.io/kubeComponent-originalURI"
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_-]+)\s*\}\}`)
+
+// ParseDevfile reads the devfile named by args.Data/Path/URL, inlines its kubernetes.uri
+// references, substitutes variables, and validates the result. Metrics are recorded at each
+// stage when args.MetricsRegisterer is set.
+func ParseDevfile(args ParserArgs) (DevfileObj, variables.VariableWarning, error) {
+	start := time.Now()
+	recorder, err := newRecorder(args)
+	if err != nil {
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+
+	content, err := readDevfileContent(args, recorder)
+	if err != nil {
+		recorder.ObserveParse("", metrics.OutcomeFailure, metrics.FailureClassFetch, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+
+	var raw v1.Devfile
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		recorder.ObserveParse("", metrics.OutcomeFailure, metrics.FailureClassUnmarshal, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, fmt.Errorf("parser: unmarshalling devfile: %w", err)
+	}
+
+	devfileData, err := data.NewDevfileData(raw)
+	if err != nil {
+		recorder.ObserveParse(raw.SchemaVersion, metrics.OutcomeFailure, metrics.FailureClassUnmarshal, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+	d := DevfileObj{Data: devfileData}
+
+	if err := resolveReferences(&d, args, recorder); err != nil {
+		recorder.ObserveParse(raw.SchemaVersion, metrics.OutcomeFailure, metrics.FailureClassFetch, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+
+	varWarning, err := substituteVariables(&d, args.ExternalVariables)
+	if err != nil {
+		recorder.ObserveParse(raw.SchemaVersion, metrics.OutcomeFailure, metrics.FailureClassVariableSubstitution, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+	recordVariableWarnings(recorder, varWarning)
+
+	if err := validateDevfile(d); err != nil {
+		recorder.ObserveParse(raw.SchemaVersion, metrics.OutcomeFailure, metrics.FailureClassValidate, time.Since(start))
+		return DevfileObj{}, variables.VariableWarning{}, err
+	}
+
+	recorder.ObserveParse(raw.SchemaVersion, metrics.OutcomeSuccess, metrics.FailureClassNone, time.Since(start))
+	return d, varWarning, nil
+}
+
+func validateDevfile(d DevfileObj) error {
+	if d.Data.GetSchemaVersion() == "" {
+		return fmt.Errorf("parser: schemaVersion is required")
+	}
+	return nil
+}
+
+func newRecorder(args ParserArgs) (*metrics.Recorder, error) {
+	if args.MetricsRegisterer == nil {
+		return nil, nil
+	}
+	recorder, err := metrics.NewRecorder(args.MetricsRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("parser: registering metrics: %w", err)
+	}
+	return recorder, nil
+}
+
+func readDevfileContent(args ParserArgs, recorder *metrics.Recorder) ([]byte, error) {
+	set := 0
+	if args.Data != nil {
+		set++
+	}
+	if args.Path != "" {
+		set++
+	}
+	if args.URL != "" {
+		set++
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("parser: exactly one of ParserArgs.Data, Path, or URL must be set")
+	}
+
+	switch {
+	case args.Data != nil:
+		return args.Data, nil
+	case args.Path != "":
+		return os.ReadFile(args.Path)
+	default:
+		content, _, err := fetchURI(args, recorder, "devfile", args.URL, "")
+		return content, err
+	}
+}
+
+// resolveReferences inlines the devfile's parent, plugin, and kubernetes.uri references in
+// place, fetching each through fetchURI so ParserArgs.ResourceResolver/HTTPClient apply
+// uniformly to all three.
+func resolveReferences(d *DevfileObj, args ParserArgs, recorder *metrics.Recorder) error {
+	content := d.Data.GetDevfileWorkspaceSpecContent()
+
+	if content.Parent != nil && content.Parent.Uri != "" {
+		// Parent, unlike Component, carries no Attributes, so devfile.io/uri-sha256 pinning
+		// isn't available here; ParserArgs.ExpectedDigests still applies.
+		if err := mergeReference(d, args, recorder, "parent", content.Parent.Uri, ""); err != nil {
+			return err
+		}
+	}
+
+	for i := range content.Components {
+		c := &content.Components[i]
+		switch {
+		case c.Kubernetes != nil && c.Kubernetes.Uri != "":
+			if err := inlineKubernetesComponent(c, args, recorder); err != nil {
+				return err
+			}
+		case c.Plugin != nil && c.Plugin.Uri != "":
+			if err := mergeReference(d, args, recorder, "plugin", c.Plugin.Uri, pinnedDigest(c.Attributes)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// pinnedDigest reads the devfile.io/uri-sha256 attribute authors can set on a Kubernetes or
+// plugin component to pin the expected digest of its fetched content, returning "" when unset.
+func pinnedDigest(attrs apiattributes.Attributes) string {
+	if attrs == nil {
+		return ""
+	}
+	var err error
+	digest := attrs.GetString(verify.URIDigestAttributeKey, &err)
+	if err != nil {
+		return ""
+	}
+	return digest
+}
+
+func inlineKubernetesComponent(c *v1.Component, args ParserArgs, recorder *metrics.Recorder) error {
+	uri := c.Kubernetes.Uri
+	fetched, verified, err := fetchURI(args, recorder, "kubernetes.uri", uri, pinnedDigest(c.Attributes))
+	if err != nil {
+		return err
+	}
+
+	c.Kubernetes.Inlined = string(fetched)
+	c.Kubernetes.Uri = ""
+	stampOriginalURI(c, uri, fetched, verified)
+	return nil
+}
+
+// mergeReference fetches the devfile at uri (a parent or plugin reference) and appends its
+// commands/components into d's, skipping any whose id/name already exists. attributeDigest is
+// the devfile.io/uri-sha256 value pinned on the referencing component, if any ("" for parent,
+// which has no Attributes to carry one).
+func mergeReference(d *DevfileObj, args ParserArgs, recorder *metrics.Recorder, kind, uri, attributeDigest string) error {
+	raw, _, err := fetchURI(args, recorder, kind, uri, attributeDigest)
+	if err != nil {
+		return err
+	}
+
+	var ref v1.Devfile
+	if err := yaml.Unmarshal(raw, &ref); err != nil {
+		return fmt.Errorf("parser: unmarshalling %s %s: %w", kind, uri, err)
+	}
+
+	content := d.Data.GetDevfileWorkspaceSpecContent()
+	content.Commands = mergeCommands(content.Commands, ref.Commands)
+	content.Components = mergeComponents(content.Components, ref.Components)
+	return nil
+}
+
+func mergeCommands(existing, incoming []v1.Command) []v1.Command {
+	seen := make(map[string]bool, len(existing))
+	for _, c := range existing {
+		seen[c.Id] = true
+	}
+	for _, c := range incoming {
+		if !seen[c.Id] {
+			existing = append(existing, c)
+		}
+	}
+	return existing
+}
+
+func mergeComponents(existing, incoming []v1.Component) []v1.Component {
+	seen := make(map[string]bool, len(existing))
+	for _, c := range existing {
+		seen[c.Name] = true
+	}
+	for _, c := range incoming {
+		if !seen[c.Name] {
+			existing = append(existing, c)
+		}
+	}
+	return existing
+}
+
+// stampOriginalURI records uri on c's attributes. verify.VerifiedDigestAttributeKey is stamped
+// alongside it only when verified is true, i.e. fetchURI actually checked content against a
+// pinned digest or signature and it matched - an unverified fetch must not produce an attribute
+// that looks identical to one a real check passed.
+func stampOriginalURI(c *v1.Component, uri string, content []byte, verified bool) {
+	if c.Attributes == nil {
+		c.Attributes = apiattributes.Attributes{}
+	}
+	c.Attributes = c.Attributes.PutString(KubeComponentOriginalURIKey, uri)
+	if verified {
+		c.Attributes = c.Attributes.PutString(verify.VerifiedDigestAttributeKey, verify.Digest(content))
+	}
+}
+
+// fetchURI resolves uri through args.ResourceResolver (or the scheme-appropriate default
+// resolver), records the fetch duration, and enforces digest/signature verification when
+// configured. A digest is expected from, in order, attributeDigest (the devfile.io/uri-sha256
+// attribute pinned on the referencing component) or args.ExpectedDigests[uri]. The returned
+// verified bool reports whether either a digest or a signature check actually ran and passed.
+func fetchURI(args ParserArgs, recorder *metrics.Recorder, kind, uri, attributeDigest string) ([]byte, bool, error) {
+	res := args.ResourceResolver
+	if res == nil {
+		res = defaultResolver(args, uri)
+	}
+
+	start := time.Now()
+	content, err := res.Resolve(context.Background(), uri)
+	recorder.ObserveFetch(kind, time.Since(start))
+	if err != nil {
+		return nil, false, fmt.Errorf("parser: resolving %s %s: %w", kind, uri, err)
+	}
+
+	var verified bool
+
+	expected := attributeDigest
+	if expected == "" {
+		expected = args.ExpectedDigests[uri]
+	}
+	if expected != "" {
+		if err := verify.VerifyDigest(uri, content, expected); err != nil {
+			return nil, false, err
+		}
+		verified = true
+	}
+
+	if args.Verifier != nil {
+		signature, err := res.Resolve(context.Background(), uri+".sig")
+		if err != nil {
+			return nil, false, fmt.Errorf("parser: fetching signature for %s: %w", uri, err)
+		}
+		if err := args.Verifier.Verify(uri, content, signature); err != nil {
+			return nil, false, err
+		}
+		verified = true
+	}
+
+	return content, verified, nil
+}
+
+// defaultResolver picks the resolver for uri's scheme: OCIResolver for oci://, HTTPResolver for
+// everything else.
+func defaultResolver(args ParserArgs, uri string) resolver.ResourceResolver {
+	if strings.HasPrefix(uri, "oci://") {
+		r := resolver.NewOCIResolver(args.HTTPClient)
+		r.DockerConfigPath = args.DockerConfigPath
+		return r
+	}
+	return resolver.NewHTTPResolver(args.HTTPClient)
+}
+
+// substituteVariables merges the devfile's own variables with external (external wins on
+// conflict), then replaces every "{{ name }}" occurrence across commands, components,
+// projects, and starter projects, recording any name left unresolved in the returned warning.
+func substituteVariables(d *DevfileObj, external map[string]string) (variables.VariableWarning, error) {
+	content := d.Data.GetDevfileWorkspaceSpecContent()
+
+	resolved := make(map[string]string, len(content.Variables)+len(external))
+	for k, v := range content.Variables {
+		resolved[k] = v
+	}
+	for k, v := range external {
+		resolved[k] = v
+	}
+	content.Variables = resolved
+
+	warning := variables.VariableWarning{
+		Commands:        map[string][]string{},
+		Components:      map[string][]string{},
+		Projects:        map[string][]string{},
+		StarterProjects: map[string][]string{},
+	}
+
+	for i := range content.Commands {
+		if err := substituteInPlace(&content.Commands[i], resolved, warning.Commands, content.Commands[i].Id); err != nil {
+			return variables.VariableWarning{}, err
+		}
+	}
+	for i := range content.Components {
+		if err := substituteInPlace(&content.Components[i], resolved, warning.Components, content.Components[i].Name); err != nil {
+			return variables.VariableWarning{}, err
+		}
+	}
+	for i := range content.Projects {
+		if err := substituteInPlace(&content.Projects[i], resolved, warning.Projects, content.Projects[i].Name); err != nil {
+			return variables.VariableWarning{}, err
+		}
+	}
+	for i := range content.StarterProjects {
+		if err := substituteInPlace(&content.StarterProjects[i], resolved, warning.StarterProjects, content.StarterProjects[i].Name); err != nil {
+			return variables.VariableWarning{}, err
+		}
+	}
+
+	return warning, nil
+}
+
+// substituteInPlace replaces "{{ name }}" occurrences anywhere in v (round-tripped through
+// YAML) with values from resolved, and records any name left unresolved under key in warnings.
+func substituteInPlace(v interface{}, resolved map[string]string, warnings map[string][]string, key string) error {
+	raw, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("parser: marshalling %T for variable substitution: %w", v, err)
+	}
+
+	var unresolved []string
+	replaced := variablePattern.ReplaceAllStringFunc(string(raw), func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if val, ok := resolved[name]; ok {
+			return val
+		}
+		unresolved = append(unresolved, name)
+		return match
+	})
+	if len(unresolved) > 0 {
+		warnings[key] = unresolved
+	}
+
+	return yaml.Unmarshal([]byte(replaced), v)
+}
+
+func recordVariableWarnings(recorder *metrics.Recorder, warning variables.VariableWarning) {
+	for _, names := range warning.Commands {
+		for range names {
+			recorder.IncVariableWarning(metrics.VariableWarningKindCommands)
+		}
+	}
+	for _, names := range warning.Components {
+		for range names {
+			recorder.IncVariableWarning(metrics.VariableWarningKindComponents)
+		}
+	}
+	for _, names := range warning.Projects {
+		for range names {
+			recorder.IncVariableWarning(metrics.VariableWarningKindProjects)
+		}
+	}
+	for _, names := range warning.StarterProjects {
+		for range names {
+			recorder.IncVariableWarning(metrics.VariableWarningKindStarterProjects)
+		}
+	}
+}
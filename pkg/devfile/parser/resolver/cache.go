@@ -0,0 +1,145 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheEntry is the last response CachingResolver saw for a given URL. Resolve shares a single
+// *cacheEntry across concurrent callers resolving the same URL (e.g. a devfile's parent and a
+// plugin that happen to reference the same remote file), so freshUntil - the only field mutated
+// after creation - is guarded by its own mutex rather than the resolver's map lock.
+type cacheEntry struct {
+	body         []byte
+	etag         string
+	lastModified string
+
+	mu         sync.Mutex
+	freshUntil time.Time
+}
+
+func (e *cacheEntry) fresh() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !e.freshUntil.IsZero() && time.Now().Before(e.freshUntil)
+}
+
+func (e *cacheEntry) setFreshUntil(t time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.freshUntil = t
+}
+
+// CachingResolver fetches over HTTP like HTTPResolver, but remembers the last response per URL
+// and revalidates with If-None-Match / If-Modified-Since instead of always re-fetching the
+// full body. This matters for controllers that call ParseDevfileAndValidate on every reconcile:
+// the same parent, plugin, or kubernetes.uri reference is typically resolved unchanged on every
+// loop. A response's Cache-Control is honored: "no-store" disables caching for that entry,
+// "no-cache" keeps the entry but revalidates it on every call, and "max-age" is used to skip
+// revalidation entirely until it elapses.
+type CachingResolver struct {
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*cacheEntry
+}
+
+// NewCachingResolver returns a CachingResolver using client, or http.DefaultClient when client
+// is nil.
+func NewCachingResolver(client *http.Client) *CachingResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &CachingResolver{client: client, cache: map[string]*cacheEntry{}}
+}
+
+func (r *CachingResolver) Resolve(ctx context.Context, uri string) ([]byte, error) {
+	r.mu.Lock()
+	entry := r.cache[uri]
+	r.mu.Unlock()
+
+	if entry != nil && entry.fresh() {
+		return entry.body, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: building request for %s: %w", uri, err)
+	}
+	if entry != nil {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: fetching %s: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		entry.setFreshUntil(cacheExpiry(resp.Header))
+		return entry.body, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver: fetching %s: unexpected status %s", uri, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: reading response from %s: %w", uri, err)
+	}
+
+	if expiry := cacheExpiry(resp.Header); !noStore(resp.Header) {
+		r.mu.Lock()
+		r.cache[uri] = &cacheEntry{
+			body:         body,
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			freshUntil:   expiry,
+		}
+		r.mu.Unlock()
+	}
+
+	return body, nil
+}
+
+func noStore(h http.Header) bool {
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		switch strings.TrimSpace(directive) {
+		case "no-store":
+			return true
+		}
+	}
+	return false
+}
+
+// cacheExpiry returns the time until which a cached response can be reused without
+// revalidation, based on Cache-Control: max-age. It returns the zero Time (never skip
+// revalidation) when max-age is absent, unparsable, or the response says no-cache.
+func cacheExpiry(h http.Header) time.Time {
+	var expiry time.Time
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "no-cache" {
+			return time.Time{}
+		}
+		if strings.HasPrefix(directive, "max-age=") && expiry.IsZero() {
+			if n, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				expiry = time.Now().Add(time.Duration(n) * time.Second)
+			}
+		}
+	}
+	return expiry
+}
@@ -0,0 +1,120 @@
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCachingResolverRevalidatesWithETag(t *testing.T) {
+	var requests int32
+	const body = "kind: Deployment\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"abc123"`)
+		if r.Header.Get("If-None-Match") == `"abc123"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	r := NewCachingResolver(server.Client())
+
+	for i := 0; i < 3; i++ {
+		got, err := r.Resolve(context.Background(), server.URL)
+		if err != nil {
+			t.Fatalf("Resolve() call %d unexpected error: %v", i, err)
+		}
+		if string(got) != body {
+			t.Errorf("Resolve() call %d = %q, want %q", i, got, body)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("expected the server to see 3 requests (revalidated each time), got %d", requests)
+	}
+}
+
+func TestCachingResolverHonorsMaxAge(t *testing.T) {
+	var requests int32
+	const body = "kind: Deployment\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	r := NewCachingResolver(server.Client())
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Resolve(context.Background(), server.URL); err != nil {
+			t.Fatalf("Resolve() call %d unexpected error: %v", i, err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the server to see 1 request (subsequent calls served from cache), got %d", requests)
+	}
+}
+
+func TestCachingResolverConcurrentResolveIsRaceFree(t *testing.T) {
+	const body = "kind: Deployment\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc123"`)
+		if r.Header.Get("If-None-Match") == `"abc123"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	r := NewCachingResolver(server.Client())
+
+	// A devfile's parent and a plugin can reference the same remote file, so Resolve must
+	// tolerate concurrent callers sharing one *cacheEntry without racing on its fields.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Resolve(context.Background(), server.URL); err != nil {
+				t.Errorf("Resolve() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCachingResolverHonorsNoStore(t *testing.T) {
+	var requests int32
+	const body = "kind: Deployment\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	r := NewCachingResolver(server.Client())
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Resolve(context.Background(), server.URL); err != nil {
+			t.Fatalf("Resolve() call %d unexpected error: %v", i, err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("expected the server to see 2 requests (no-store disables caching), got %d", requests)
+	}
+}
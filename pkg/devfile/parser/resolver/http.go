@@ -0,0 +1,48 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPResolver fetches a devfile reference over plain HTTP(S) using Client, which callers can
+// configure with custom TLS roots, a bearer token for private Git/registry hosts, a proxy, or a
+// request timeout. It is the resolver the parser falls back to when
+// parser.ParserArgs.ResourceResolver is left unset.
+type HTTPResolver struct {
+	Client *http.Client
+}
+
+// NewHTTPResolver returns an HTTPResolver using client, or http.DefaultClient when client is
+// nil.
+func NewHTTPResolver(client *http.Client) *HTTPResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPResolver{Client: client}
+}
+
+func (r *HTTPResolver) Resolve(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: building request for %s: %w", uri, err)
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: fetching %s: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver: fetching %s: unexpected status %s", uri, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: reading response from %s: %w", uri, err)
+	}
+	return body, nil
+}
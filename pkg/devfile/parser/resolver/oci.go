@@ -0,0 +1,289 @@
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devfile/library/pkg/devfile/parser/verify"
+)
+
+// DefaultLayerMediaType is the artifact layer media type OCIResolver extracts from a manifest
+// when MediaType is left empty.
+const DefaultLayerMediaType = "application/vnd.devfile.layer.v1+yaml"
+
+// ociManifest is the subset of the OCI image manifest spec OCIResolver needs.
+type ociManifest struct {
+	SchemaVersion int        `json:"schemaVersion"`
+	MediaType     string     `json:"mediaType"`
+	Layers        []ociLayer `json:"layers"`
+	Config        ociLayer   `json:"config"`
+}
+
+type ociLayer struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// OCIResolver resolves `oci://registry/repo:tag` and `oci://registry/repo@sha256:...`
+// references by pulling a single-layer artifact over the distribution-spec HTTP API
+// (https://github.com/opencontainers/distribution-spec). It is used alongside HTTPResolver /
+// CachingResolver for kubernetes.uri, parent.uri, and plugin references that point at a
+// registry instead of a plain HTTP(S) host.
+type OCIResolver struct {
+	Client *http.Client
+
+	// MediaType is the layer media type to extract from the manifest. Defaults to
+	// DefaultLayerMediaType when empty.
+	MediaType string
+
+	// DockerConfigPath points at a docker config.json used for registry auth. When empty,
+	// DockerConfigPath falls back to $DOCKER_CONFIG, then ~/.docker/config.json.
+	DockerConfigPath string
+}
+
+// NewOCIResolver returns an OCIResolver using client, or http.DefaultClient when client is nil.
+func NewOCIResolver(client *http.Client) *OCIResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &OCIResolver{Client: client}
+}
+
+// ociRef is a parsed `oci://registry/repository[:tag][@digest]` reference.
+type ociRef struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+func parseOCIRef(uri string) (ociRef, error) {
+	rest := strings.TrimPrefix(uri, "oci://")
+	if rest == uri {
+		return ociRef{}, fmt.Errorf("resolver: %q is not an oci:// reference", uri)
+	}
+
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return ociRef{}, fmt.Errorf("resolver: %q is missing a repository path", uri)
+	}
+	ref := ociRef{registry: rest[:slash]}
+	rest = rest[slash+1:]
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		ref.digest = rest[at+1:]
+		rest = rest[:at]
+	}
+	if colon := strings.LastIndex(rest, ":"); colon >= 0 {
+		ref.tag = rest[colon+1:]
+		rest = rest[:colon]
+	} else if ref.digest == "" {
+		ref.tag = "latest"
+	}
+	ref.repository = rest
+
+	if ref.repository == "" {
+		return ociRef{}, fmt.Errorf("resolver: %q is missing a repository path", uri)
+	}
+	return ref, nil
+}
+
+func (ref ociRef) reference() string {
+	if ref.digest != "" {
+		return ref.digest
+	}
+	return ref.tag
+}
+
+func (r *OCIResolver) Resolve(ctx context.Context, uri string) ([]byte, error) {
+	ref, err := parseOCIRef(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType := r.MediaType
+	if mediaType == "" {
+		mediaType = DefaultLayerMediaType
+	}
+
+	manifest, manifestBytes, err := r.fetchManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// A pinned digest (oci://...@sha256:...) names the manifest itself, not any one of its
+	// layers, so it must be checked against the manifest bytes just fetched.
+	if ref.digest != "" {
+		if err := verifyOCIDigest(manifestBytes, ref.digest); err != nil {
+			return nil, fmt.Errorf("resolver: %s: manifest %w", uri, err)
+		}
+	}
+
+	layer, err := singleLayer(manifest, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: %s: %w", uri, err)
+	}
+
+	blob, err := r.fetchBlob(ctx, ref, layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyOCIDigest(blob, layer.Digest); err != nil {
+		return nil, fmt.Errorf("resolver: %s: layer %w", uri, err)
+	}
+
+	return blob, nil
+}
+
+func singleLayer(manifest ociManifest, mediaType string) (ociLayer, error) {
+	var matches []ociLayer
+	for _, l := range manifest.Layers {
+		if l.MediaType == mediaType {
+			matches = append(matches, l)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ociLayer{}, fmt.Errorf("no layer with media type %q in manifest", mediaType)
+	case 1:
+		return matches[0], nil
+	default:
+		return ociLayer{}, fmt.Errorf("manifest has %d layers with media type %q, expected exactly 1", len(matches), mediaType)
+	}
+}
+
+// verifyOCIDigest checks content against digest, a "sha256:<hex>" reference as used in OCI
+// manifests and layer descriptors, reusing the parser's verify package for the actual
+// comparison rather than duplicating it.
+func verifyOCIDigest(content []byte, digest string) error {
+	algoAndHex := strings.SplitN(digest, ":", 2)
+	if len(algoAndHex) != 2 || algoAndHex[0] != "sha256" {
+		return fmt.Errorf("unsupported digest %q", digest)
+	}
+	return verify.VerifyDigest(digest, content, algoAndHex[1])
+}
+
+// fetchManifest returns both the decoded manifest and the raw bytes it was decoded from, since
+// a pinned digest reference must be checked against the exact bytes the registry served.
+func (r *OCIResolver) fetchManifest(ctx context.Context, ref ociRef) (ociManifest, []byte, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.registry, ref.repository, ref.reference())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return ociManifest{}, nil, fmt.Errorf("resolver: building manifest request for %s/%s: %w", ref.registry, ref.repository, err)
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+	if err := r.setAuth(req, ref.registry); err != nil {
+		return ociManifest{}, nil, err
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return ociManifest{}, nil, fmt.Errorf("resolver: fetching manifest for %s/%s: %w", ref.registry, ref.repository, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ociManifest{}, nil, fmt.Errorf("resolver: fetching manifest for %s/%s: unexpected status %s", ref.registry, ref.repository, resp.Status)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ociManifest{}, nil, fmt.Errorf("resolver: reading manifest for %s/%s: %w", ref.registry, ref.repository, err)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return ociManifest{}, nil, fmt.Errorf("resolver: decoding manifest for %s/%s: %w", ref.registry, ref.repository, err)
+	}
+	return manifest, raw, nil
+}
+
+func (r *OCIResolver) fetchBlob(ctx context.Context, ref ociRef, digest string) ([]byte, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/blobs/%s", ref.registry, ref.repository, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: building blob request for %s/%s: %w", ref.registry, ref.repository, err)
+	}
+	if err := r.setAuth(req, ref.registry); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("resolver: fetching blob %s from %s/%s: %w", digest, ref.registry, ref.repository, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver: fetching blob %s from %s/%s: unexpected status %s", digest, ref.registry, ref.repository, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// setAuth attaches a Basic-auth header for registry, read out of the resolver's
+// DockerConfigPath (or $DOCKER_CONFIG, or ~/.docker/config.json), if one is configured. It is a
+// no-op when no credentials are found for registry, so air-gapped or anonymous pulls keep
+// working.
+func (r *OCIResolver) setAuth(req *http.Request, registry string) error {
+	auth, err := dockerConfigAuth(r.DockerConfigPath, registry)
+	if err != nil {
+		return fmt.Errorf("resolver: reading docker config for %s: %w", registry, err)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", "Basic "+auth)
+	}
+	return nil
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+}
+
+func dockerConfigAuth(configPath, registry string) (string, error) {
+	if configPath == "" {
+		// Like the docker CLI, $DOCKER_CONFIG names the directory holding config.json.
+		if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+			configPath = filepath.Join(dir, "config.json")
+		}
+	}
+	if configPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		configPath = filepath.Join(home, ".docker", "config.json")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", err
+	}
+
+	if entry, ok := cfg.Auths[registry]; ok {
+		if _, err := base64.StdEncoding.DecodeString(entry.Auth); err != nil {
+			return "", fmt.Errorf("auth for %s is not valid base64", registry)
+		}
+		return entry.Auth, nil
+	}
+	return "", nil
+}
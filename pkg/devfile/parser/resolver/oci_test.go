@@ -0,0 +1,137 @@
+package resolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOCIResolverResolve(t *testing.T) {
+	const layerContent = "kind: Deployment\nmetadata:\n  name: my-python\n"
+	sum := sha256.Sum256([]byte(layerContent))
+	layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/devfile/outerloop/manifests/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		manifest := ociManifest{
+			SchemaVersion: 2,
+			MediaType:     "application/vnd.oci.image.manifest.v1+json",
+			Layers: []ociLayer{
+				{MediaType: DefaultLayerMediaType, Digest: layerDigest, Size: int64(len(layerContent))},
+			},
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		_ = json.NewEncoder(w).Encode(manifest)
+	})
+	mux.HandleFunc(fmt.Sprintf("/v2/devfile/outerloop/blobs/%s", layerDigest), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(layerContent))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	registry := server.Listener.Addr().String()
+	r := NewOCIResolver(server.Client())
+
+	got, err := r.Resolve(context.Background(), fmt.Sprintf("oci://%s/devfile/outerloop:1.0.0", registry))
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+	if string(got) != layerContent {
+		t.Errorf("Resolve() = %q, want %q", got, layerContent)
+	}
+}
+
+func TestOCIResolverResolvePinnedManifestDigest(t *testing.T) {
+	const layerContent = "kind: Deployment\n"
+	sum := sha256.Sum256([]byte(layerContent))
+	layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	manifestBytes, err := json.Marshal(ociManifest{
+		SchemaVersion: 2,
+		Layers: []ociLayer{
+			{MediaType: DefaultLayerMediaType, Digest: layerDigest, Size: int64(len(layerContent))},
+		},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+	manifestSum := sha256.Sum256(manifestBytes)
+	manifestDigest := "sha256:" + hex.EncodeToString(manifestSum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/devfile/outerloop/manifests/"+manifestDigest, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(manifestBytes)
+	})
+	mux.HandleFunc("/v2/devfile/outerloop/blobs/"+layerDigest, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(layerContent))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	registry := server.Listener.Addr().String()
+	r := NewOCIResolver(server.Client())
+
+	// The pinned digest matches the manifest bytes the server serves: this must succeed.
+	uri := fmt.Sprintf("oci://%s/devfile/outerloop@%s", registry, manifestDigest)
+	got, err := r.Resolve(context.Background(), uri)
+	if err != nil {
+		t.Fatalf("Resolve() with a correctly pinned manifest digest returned error: %v", err)
+	}
+	if string(got) != layerContent {
+		t.Errorf("Resolve() = %q, want %q", got, layerContent)
+	}
+
+	// A digest that doesn't match what the registry actually serves must be rejected.
+	mismatchURI := fmt.Sprintf("oci://%s/devfile/outerloop@sha256:%s", registry, strings.Repeat("0", 64))
+	mux.HandleFunc("/v2/devfile/outerloop/manifests/sha256:"+strings.Repeat("0", 64), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(manifestBytes)
+	})
+	if _, err := r.Resolve(context.Background(), mismatchURI); err == nil {
+		t.Error("Resolve() expected an error for a pinned digest that doesn't match the served manifest, got nil")
+	}
+}
+
+func TestParseOCIRef(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    ociRef
+		wantErr bool
+	}{
+		{
+			uri:  "oci://registry.example.com/devfile/outerloop:1.0.0",
+			want: ociRef{registry: "registry.example.com", repository: "devfile/outerloop", tag: "1.0.0"},
+		},
+		{
+			uri:  "oci://registry.example.com/devfile/outerloop@sha256:abc",
+			want: ociRef{registry: "registry.example.com", repository: "devfile/outerloop", digest: "sha256:abc"},
+		},
+		{
+			uri:  "oci://registry.example.com/devfile/outerloop",
+			want: ociRef{registry: "registry.example.com", repository: "devfile/outerloop", tag: "latest"},
+		},
+		{
+			uri:     "https://registry.example.com/devfile/outerloop:1.0.0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			got, err := parseOCIRef(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOCIRef(%q) error = %v, wantErr %v", tt.uri, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parseOCIRef(%q) = %+v, want %+v", tt.uri, got, tt.want)
+			}
+		})
+	}
+}
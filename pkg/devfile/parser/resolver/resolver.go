@@ -0,0 +1,15 @@
+// Package resolver provides pluggable fetchers for the remote content a devfile can
+// reference: kubernetes.uri on a Kubernetes component, and parent/plugin URIs. Callers wire a
+// ResourceResolver in through parser.ParserArgs.ResourceResolver (or just set
+// parser.ParserArgs.HTTPClient to keep the default HTTP-based resolution but with a custom
+// transport, TLS roots, bearer token, or timeout) to control how that content is fetched.
+package resolver
+
+import "context"
+
+// ResourceResolver fetches the raw bytes a devfile reference points at. Implementations are
+// expected to be safe for concurrent use, since parent/plugin/component resolution can happen
+// concurrently across devfiles.
+type ResourceResolver interface {
+	Resolve(ctx context.Context, uri string) ([]byte, error)
+}
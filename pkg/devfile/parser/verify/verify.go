@@ -0,0 +1,106 @@
+// Package verify checks the integrity of remote content the devfile parser inlines: a
+// kubernetes.uri component or a parent/plugin reference. A compromised or flaky mirror can
+// otherwise silently change the manifest a devfile ends up applying to a cluster.
+//
+// Two independent checks are supported. Authors can pin an expected sha256 digest, either via
+// the URIDigestAttributeKey attribute on a Kubernetes or plugin component or via
+// parser.ParserArgs.ExpectedDigests keyed by URI (the only way a parent reference can be
+// pinned); VerifyDigest fails fast if the fetched bytes
+// don't match. Separately, parser.ParserArgs.Verifier can validate a detached signature fetched
+// from "<uri>.sig" against caller-supplied public-key material.
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	// URIDigestAttributeKey is the devfile attribute authors can set on a Kubernetes or plugin
+	// component to pin the expected sha256 digest of the fetched content. The parser does not
+	// read it for a parent reference.
+	URIDigestAttributeKey = "devfile.io/uri-sha256"
+
+	// VerifiedDigestAttributeKey is stamped onto an inlined Kubernetes component, alongside the
+	// existing devfile.io/kubeComponent-originalURI, once its content has passed a digest or
+	// signature check, so downstream controllers can prove provenance without re-fetching.
+	// Parent and plugin references are merged rather than inlined and are not stamped.
+	VerifiedDigestAttributeKey = "devfile.io/kubeComponent-digest"
+)
+
+// DigestMismatchError is returned by VerifyDigest when fetched content doesn't hash to the
+// expected digest.
+type DigestMismatchError struct {
+	URI      string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("content fetched from %q has sha256 digest %q, expected %q", e.URI, e.Actual, e.Expected)
+}
+
+// Digest returns the hex-encoded sha256 digest of content, in the same form expected by
+// URIDigestAttributeKey, ParserArgs.ExpectedDigests, and VerifiedDigestAttributeKey.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyDigest checks content against expected, a hex-encoded sha256 digest, and returns a
+// *DigestMismatchError if they don't match. An empty expected skips verification, so callers
+// that don't pin a digest for a given URI see no behavior change.
+func VerifyDigest(uri string, content []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if actual := Digest(content); actual != expected {
+		return &DigestMismatchError{URI: uri, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// Verifier validates a detached signature, fetched from "<uri>.sig", of content fetched from
+// uri. Implementations carry whatever public-key material they need; this package ships
+// ECDSAVerifier for the raw ECDSA signatures cosign produces, and the same interface can be
+// implemented against an OpenPGP keyring.
+type Verifier interface {
+	Verify(uri string, content, signature []byte) error
+}
+
+// ECDSAVerifier verifies a raw ASN.1 ECDSA signature of content's sha256 digest against a fixed
+// public key, the format cosign's --output-signature produces for a non-keyless signing key.
+type ECDSAVerifier struct {
+	PublicKey *ecdsa.PublicKey
+}
+
+// NewECDSAVerifierFromPEM parses a PEM-encoded PKIX public key, such as a cosign.pub file.
+func NewECDSAVerifierFromPEM(pemBytes []byte) (*ECDSAVerifier, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("verify: no PEM block found in public key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("verify: parsing public key: %w", err)
+	}
+
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verify: public key is %T, expected an ECDSA public key", pub)
+	}
+	return &ECDSAVerifier{PublicKey: ecdsaPub}, nil
+}
+
+func (v *ECDSAVerifier) Verify(uri string, content, signature []byte) error {
+	digest := sha256.Sum256(content)
+	if !ecdsa.VerifyASN1(v.PublicKey, digest[:], signature) {
+		return fmt.Errorf("verify: signature verification failed for %s", uri)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestVerifyDigest(t *testing.T) {
+	content := []byte("kind: Deployment\n")
+	digest := Digest(content)
+
+	if err := VerifyDigest("http://example.com/d.yaml", content, digest); err != nil {
+		t.Errorf("VerifyDigest() with matching digest returned error: %v", err)
+	}
+
+	if err := VerifyDigest("http://example.com/d.yaml", content, ""); err != nil {
+		t.Errorf("VerifyDigest() with no expected digest returned error: %v", err)
+	}
+
+	err := VerifyDigest("http://example.com/d.yaml", content, "deadbeef")
+	if err == nil {
+		t.Fatal("VerifyDigest() with mismatched digest returned nil error")
+	}
+	var mismatch *DigestMismatchError
+	if _, ok := err.(*DigestMismatchError); !ok {
+		t.Errorf("VerifyDigest() error type = %T, want %T", err, mismatch)
+	}
+}
+
+func TestECDSAVerifier(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() unexpected error: %v", err)
+	}
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() unexpected error: %v", err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes})
+
+	verifier, err := NewECDSAVerifierFromPEM(pubPEM)
+	if err != nil {
+		t.Fatalf("NewECDSAVerifierFromPEM() unexpected error: %v", err)
+	}
+
+	content := []byte("kind: Deployment\n")
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("SignASN1() unexpected error: %v", err)
+	}
+
+	if err := verifier.Verify("http://example.com/d.yaml", content, sig); err != nil {
+		t.Errorf("Verify() with a valid signature returned error: %v", err)
+	}
+
+	if err := verifier.Verify("http://example.com/d.yaml", []byte("tampered"), sig); err == nil {
+		t.Error("Verify() with tampered content returned nil error")
+	}
+}